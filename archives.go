@@ -0,0 +1,182 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/akhenakh/zim-cgo/zim"
+)
+
+// LoadedArchive bundles an opened ZIM archive with the name it is registered
+// under, derived from its filename on disk.
+type LoadedArchive struct {
+	Name    string
+	Path    string
+	Archive *zim.Archive
+}
+
+// ArchiveRegistry keeps track of every ZIM archive opened for this process,
+// keyed by a short name so tools can disambiguate across archives.
+type ArchiveRegistry struct {
+	mu      sync.RWMutex
+	byName  map[string]*LoadedArchive
+	ordered []*LoadedArchive
+}
+
+// NewArchiveRegistry returns an empty registry.
+func NewArchiveRegistry() *ArchiveRegistry {
+	return &ArchiveRegistry{byName: make(map[string]*LoadedArchive)}
+}
+
+// Add opens the ZIM file at path and registers it under a name derived from
+// its filename, disambiguating if that name is already taken.
+func (r *ArchiveRegistry) Add(path string) (*LoadedArchive, error) {
+	archive, err := zim.NewArchive(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open zim archive at %s: %w", path, err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	name := archiveNameFromPath(path)
+	if _, taken := r.byName[name]; taken {
+		base := name
+		for i := 2; ; i++ {
+			candidate := fmt.Sprintf("%s-%d", base, i)
+			if _, taken := r.byName[candidate]; !taken {
+				name = candidate
+				break
+			}
+		}
+	}
+
+	loaded := &LoadedArchive{Name: name, Path: path, Archive: archive}
+	r.byName[name] = loaded
+	r.ordered = append(r.ordered, loaded)
+	return loaded, nil
+}
+
+// Get looks up an archive by its registered name.
+func (r *ArchiveRegistry) Get(name string) (*LoadedArchive, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	a, ok := r.byName[name]
+	return a, ok
+}
+
+// All returns every loaded archive in the order they were added.
+func (r *ArchiveRegistry) All() []*LoadedArchive {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	out := make([]*LoadedArchive, len(r.ordered))
+	copy(out, r.ordered)
+	return out
+}
+
+// Len reports how many archives are currently loaded.
+func (r *ArchiveRegistry) Len() int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return len(r.ordered)
+}
+
+// Only returns the single loaded archive when exactly one is loaded.
+func (r *ArchiveRegistry) Only() (*LoadedArchive, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if len(r.ordered) != 1 {
+		return nil, false
+	}
+	return r.ordered[0], true
+}
+
+// Close shuts down every loaded archive. It reports no errors.
+func (r *ArchiveRegistry) Close() {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for _, a := range r.ordered {
+		a.Archive.Close()
+	}
+}
+
+// archiveNameFromPath derives a short registry name from a ZIM file path,
+// e.g. "/data/wikipedia_en_all_nopic.zim" -> "wikipedia_en_all_nopic".
+func archiveNameFromPath(path string) string {
+	base := filepath.Base(path)
+	return strings.TrimSuffix(base, filepath.Ext(base))
+}
+
+// splitArchiveQualifiedPath splits a compound "archive:path" identifier into
+// its archive name and entry path. It returns ok=false if path does not
+// contain the "archive:" prefix form.
+func splitArchiveQualifiedPath(path string) (archiveName, entryPath string, ok bool) {
+	idx := strings.Index(path, ":")
+	if idx <= 0 {
+		return "", "", false
+	}
+	// The split is at the first ':' only, so any later colons stay in the
+	// entry path. The prefix is not checked against the loaded archives here.
+	return path[:idx], path[idx+1:], true
+}
+
+// multiFlag accumulates repeated occurrences of a string flag, e.g. passing
+// "-z" more than once on the command line.
+type multiFlag []string
+
+func (m *multiFlag) String() string {
+	if m == nil {
+		return ""
+	}
+	return strings.Join(*m, ",")
+}
+
+func (m *multiFlag) Set(value string) error {
+	*m = append(*m, value)
+	return nil
+}
+
+// collectZimPaths merges the explicit -z paths with every *.zim file found
+// directly inside zimDir (if set), and returns a de-duplicated list: the
+// explicit paths first, in the order given, then the directory's files in
+// sorted order.
+func collectZimPaths(explicit []string, zimDir string) ([]string, error) {
+	seen := make(map[string]bool)
+	var paths []string
+
+	addPath := func(p string) {
+		if p == "" || seen[p] {
+			return
+		}
+		seen[p] = true
+		paths = append(paths, p)
+	}
+
+	for _, p := range explicit {
+		addPath(p)
+	}
+
+	if zimDir != "" {
+		entries, err := os.ReadDir(zimDir)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read -zdir %s: %w", zimDir, err)
+		}
+		var found []string
+		for _, e := range entries {
+			if e.IsDir() || !strings.EqualFold(filepath.Ext(e.Name()), ".zim") {
+				continue
+			}
+			found = append(found, filepath.Join(zimDir, e.Name()))
+		}
+		sort.Strings(found)
+		for _, p := range found {
+			addPath(p)
+		}
+	}
+
+	return paths, nil
+}
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestArchiveNameFromPath(t *testing.T) {
+	cases := []struct {
+		name string
+		path string
+		want string
+	}{
+		{"plain file", "/data/wikipedia_en_all_nopic.zim", "wikipedia_en_all_nopic"},
+		{"relative path", "wiktionary_en.zim", "wiktionary_en"},
+		{"no extension", "/data/archive", "archive"},
+		{"dotted name", "/data/wikipedia.en.all.zim", "wikipedia.en.all"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := archiveNameFromPath(tc.path); got != tc.want {
+				t.Errorf("archiveNameFromPath(%q) = %q, want %q", tc.path, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestSplitArchiveQualifiedPath(t *testing.T) {
+	cases := []struct {
+		name          string
+		path          string
+		wantArchive   string
+		wantEntryPath string
+		wantOK        bool
+	}{
+		{"qualified path", "wikipedia:A/Cat", "wikipedia", "A/Cat", true},
+		{"qualified path with colon in entry", "wikipedia:A/Time:12:00", "wikipedia", "A/Time:12:00", true},
+		{"no colon", "A/Cat", "", "", false},
+		{"leading colon", ":A/Cat", "", "", false},
+		{"empty string", "", "", "", false},
+		{"trailing colon", "wikipedia:", "wikipedia", "", true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			archive, entryPath, ok := splitArchiveQualifiedPath(tc.path)
+			if ok != tc.wantOK {
+				t.Fatalf("splitArchiveQualifiedPath(%q) ok = %v, want %v", tc.path, ok, tc.wantOK)
+			}
+			if !ok {
+				return
+			}
+			if archive != tc.wantArchive || entryPath != tc.wantEntryPath {
+				t.Errorf("splitArchiveQualifiedPath(%q) = (%q, %q), want (%q, %q)",
+					tc.path, archive, entryPath, tc.wantArchive, tc.wantEntryPath)
+			}
+		})
+	}
+}
+
+func TestCollectZimPaths(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"a.zim", "b.ZIM", "c.txt", "sub"} {
+		if name == "sub" {
+			if err := os.Mkdir(filepath.Join(dir, name), 0o755); err != nil {
+				t.Fatal(err)
+			}
+			continue
+		}
+		if err := os.WriteFile(filepath.Join(dir, name), nil, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	aPath := filepath.Join(dir, "a.zim")
+	bPath := filepath.Join(dir, "b.ZIM")
+
+	cases := []struct {
+		name     string
+		explicit []string
+		zimDir   string
+		want     []string
+		wantErr  bool
+	}{
+		{
+			name:     "explicit only",
+			explicit: []string{"/other/x.zim", "/other/y.zim"},
+			want:     []string{"/other/x.zim", "/other/y.zim"},
+		},
+		{
+			name:     "dir only, non-zim and subdirs ignored, case-insensitive extension",
+			explicit: nil,
+			zimDir:   dir,
+			want:     []string{aPath, bPath},
+		},
+		{
+			name:     "explicit de-duplicated against dir",
+			explicit: []string{aPath},
+			zimDir:   dir,
+			want:     []string{aPath, bPath},
+		},
+		{
+			name:     "explicit duplicates collapsed",
+			explicit: []string{"/other/x.zim", "/other/x.zim"},
+			want:     []string{"/other/x.zim"},
+		},
+		{
+			name:     "missing dir errors",
+			explicit: nil,
+			zimDir:   filepath.Join(dir, "does-not-exist"),
+			wantErr:  true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := collectZimPaths(tc.explicit, tc.zimDir)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error, got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("collectZimPaths(%v, %q) = %v, want %v", tc.explicit, tc.zimDir, got, tc.want)
+			}
+		})
+	}
+}
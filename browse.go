@@ -0,0 +1,96 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/akhenakh/zim-mcp/internal/snippet"
+)
+
+// mountBrowseRoutes registers the read-only Kiwix-style HTTP browse surface
+// onto mux: a JSON archive listing, per-archive article/asset serving, and
+// per-archive search, all backed by the same archive registry the MCP tools
+// use. It exists purely so a human (or non-MCP tooling) can inspect what the
+// LLM sees without going through the MCP protocol.
+func mountBrowseRoutes(mux *http.ServeMux, registry *ArchiveRegistry, caches *Caches) {
+	mux.HandleFunc("GET /archives", func(w http.ResponseWriter, r *http.Request) {
+		loadedArchives := registry.All()
+		infos := make([]ArchiveInfo, 0, len(loadedArchives))
+		for _, loaded := range loadedArchives {
+			infos = append(infos, describeArchive(loaded))
+		}
+		writeJSON(w, http.StatusOK, ListArchivesResponse{Archives: infos})
+	})
+
+	mux.HandleFunc("GET /{archive}/A/{path...}", func(w http.ResponseWriter, r *http.Request) {
+		loaded, ok := registry.Get(r.PathValue("archive"))
+		if !ok {
+			http.Error(w, "unknown archive", http.StatusNotFound)
+			return
+		}
+
+		entry, err := loaded.Archive.GetEntryByPath(r.PathValue("path"))
+		if err != nil {
+			http.NotFound(w, r)
+			return
+		}
+		defer entry.Close()
+
+		// Follow redirects transparently, same as read_article/read_raw.
+		item, err := entry.GetItem(true)
+		if err != nil {
+			http.Error(w, "failed to load entry", http.StatusInternalServerError)
+			return
+		}
+		defer item.Close()
+
+		if mime := item.GetMimetype(); mime != "" {
+			w.Header().Set("Content-Type", mime)
+		}
+		w.Write(item.GetData())
+	})
+
+	mux.HandleFunc("GET /{archive}/search", func(w http.ResponseWriter, r *http.Request) {
+		loaded, ok := registry.Get(r.PathValue("archive"))
+		if !ok {
+			http.Error(w, "unknown archive", http.StatusNotFound)
+			return
+		}
+
+		query := r.URL.Query().Get("q")
+		if query == "" {
+			http.Error(w, "missing required query parameter: q", http.StatusBadRequest)
+			return
+		}
+
+		count := 20
+		if c := r.URL.Query().Get("count"); c != "" {
+			if parsed, err := strconv.Atoi(c); err == nil && parsed > 0 {
+				count = parsed
+			}
+		}
+
+		if !loaded.Archive.HasFulltextIndex() {
+			http.Error(w, "this archive does not contain a full-text search index", http.StatusNotImplemented)
+			return
+		}
+
+		items, err := searchArchive(caches, loaded, query, count, true, snippet.DefaultLength)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if items == nil {
+			items = []SearchResultItem{}
+		}
+
+		writeJSON(w, http.StatusOK, SearchResponse{Results: items})
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
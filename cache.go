@@ -0,0 +1,134 @@
+package main
+
+import (
+	"sync/atomic"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+	"github.com/hashicorp/golang-lru/v2/expirable"
+)
+
+// searchCacheTTL bounds how long a cached search_zim response stays valid.
+// Search results are cheap to recompute and we would rather not serve stale
+// hits indefinitely, unlike converted articles which never change.
+const searchCacheTTL = 5 * time.Minute
+
+// articleCacheKey identifies a single converted article. Keying on the
+// archive's file path (rather than its registry name) means a cache entry
+// is naturally invalidated if an archive is ever reloaded from a different
+// file under the same name.
+type articleCacheKey struct {
+	archivePath string
+	entryPath   string
+}
+
+// searchCacheKey identifies a single search_zim call. highlight and
+// snippetLength are included alongside the (archivePath, query, count)
+// tuple from the request so that a cached response always matches what the
+// caller asked for.
+type searchCacheKey struct {
+	archivePath   string
+	query         string
+	count         int
+	highlight     bool
+	snippetLength int
+}
+
+// cacheCounters tracks hit/miss/eviction counts for a single cache.
+type cacheCounters struct {
+	hits      atomic.Int64
+	misses    atomic.Int64
+	evictions atomic.Int64
+}
+
+func (c *cacheCounters) stats(size int) CacheStats {
+	return CacheStats{
+		Size:      size,
+		Hits:      c.hits.Load(),
+		Misses:    c.misses.Load(),
+		Evictions: c.evictions.Load(),
+	}
+}
+
+// Caches holds the process-wide article and search caches along with their
+// hit/miss/eviction counters.
+type Caches struct {
+	articles     *lru.Cache[articleCacheKey, string]
+	articleStats cacheCounters
+
+	searches    *expirable.LRU[searchCacheKey, []SearchResultItem]
+	searchStats cacheCounters
+}
+
+// NewCaches builds the article and search caches with the given sizes.
+func NewCaches(articleSize, searchSize int) (*Caches, error) {
+	c := &Caches{}
+
+	articles, err := lru.NewWithEvict[articleCacheKey, string](articleSize, func(articleCacheKey, string) {
+		c.articleStats.evictions.Add(1)
+	})
+	if err != nil {
+		return nil, err
+	}
+	c.articles = articles
+
+	c.searches = expirable.NewLRU[searchCacheKey, []SearchResultItem](searchSize, func(searchCacheKey, []SearchResultItem) {
+		c.searchStats.evictions.Add(1)
+	}, searchCacheTTL)
+
+	return c, nil
+}
+
+// GetArticle returns a previously converted article's Markdown, if cached.
+func (c *Caches) GetArticle(key articleCacheKey) (string, bool) {
+	md, ok := c.articles.Get(key)
+	if ok {
+		c.articleStats.hits.Add(1)
+	} else {
+		c.articleStats.misses.Add(1)
+	}
+	return md, ok
+}
+
+// PutArticle stores a converted article's Markdown.
+func (c *Caches) PutArticle(key articleCacheKey, markdown string) {
+	c.articles.Add(key, markdown)
+}
+
+// GetSearch returns a previously computed set of search_zim results, if cached.
+func (c *Caches) GetSearch(key searchCacheKey) ([]SearchResultItem, bool) {
+	results, ok := c.searches.Get(key)
+	if ok {
+		c.searchStats.hits.Add(1)
+	} else {
+		c.searchStats.misses.Add(1)
+	}
+	return results, ok
+}
+
+// PutSearch stores a set of search_zim results.
+func (c *Caches) PutSearch(key searchCacheKey, results []SearchResultItem) {
+	c.searches.Add(key, results)
+}
+
+// CacheStats is the per-cache slice of the cache_stats tool response.
+type CacheStats struct {
+	Size      int   `json:"size"`
+	Hits      int64 `json:"hits"`
+	Misses    int64 `json:"misses"`
+	Evictions int64 `json:"evictions"`
+}
+
+// CacheStatsResponse is the JSON envelope for the cache_stats tool.
+type CacheStatsResponse struct {
+	Articles CacheStats `json:"articles"`
+	Searches CacheStats `json:"searches"`
+}
+
+// Stats snapshots the current hit/miss/eviction counters for both caches.
+func (c *Caches) Stats() CacheStatsResponse {
+	return CacheStatsResponse{
+		Articles: c.articleStats.stats(c.articles.Len()),
+		Searches: c.searchStats.stats(c.searches.Len()),
+	}
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/JohannesKaufmann/html-to-markdown/v2/converter"
+	"github.com/JohannesKaufmann/html-to-markdown/v2/plugin/base"
+	"github.com/JohannesKaufmann/html-to-markdown/v2/plugin/commonmark"
+)
+
+// benchArticleHTML is a representative article body: a handful of
+// paragraphs, an internal link, an external link, and an image, which is
+// what a typical ZIM article looks like after entry.GetItem().GetData().
+const benchArticleHTML = `
+<html><body>
+<h1>Example Article</h1>
+<p>This is a <a href="/A/Another_Article">link to another article</a> and a
+<a href="https://example.com">link to an external site</a>.</p>
+<img src="image.png" alt="diagram"/>
+<p>Lorem ipsum dolor sit amet, consectetur adipiscing elit. Sed do eiusmod
+tempor incididunt ut labore et dolore magna aliqua.</p>
+<p>Ut enim ad minim veniam, quis nostrud exercitation ullamco laboris nisi ut
+aliquip ex ea commodo consequat.</p>
+</body></html>`
+
+func newBenchConverter() *converter.Converter {
+	conv := converter.NewConverter(
+		converter.WithPlugins(
+			base.NewBasePlugin(),
+			commonmark.NewCommonmarkPlugin(),
+		),
+	)
+	conv.Register.TagType("img", converter.TagTypeRemove, converter.PriorityEarly)
+	return conv
+}
+
+// BenchmarkConvertArticle measures the cost of the HTML->Markdown
+// conversion and link-cleanup regex passes that read_article pays on every
+// cache miss.
+func BenchmarkConvertArticle(b *testing.B) {
+	conv := newBenchConverter()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		md, err := conv.ConvertString(benchArticleHTML)
+		if err != nil {
+			b.Fatal(err)
+		}
+		_ = cleanArticleMarkdown(md)
+	}
+}
+
+// BenchmarkArticleCacheHit measures the cost of serving the same article
+// from the LRU cache, to quantify the win from BenchmarkConvertArticle.
+func BenchmarkArticleCacheHit(b *testing.B) {
+	caches, err := NewCaches(512, 128)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	conv := newBenchConverter()
+	md, err := conv.ConvertString(benchArticleHTML)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	key := articleCacheKey{archivePath: "bench.zim", entryPath: "A/Example_Article"}
+	caches.PutArticle(key, cleanArticleMarkdown(md))
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, ok := caches.GetArticle(key); !ok {
+			b.Fatal("expected cache hit")
+		}
+	}
+}
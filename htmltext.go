@@ -0,0 +1,24 @@
+package main
+
+import (
+	"html"
+	"regexp"
+	"strings"
+)
+
+var (
+	scriptRegexp     = regexp.MustCompile(`(?is)<script\b.*?</script\s*>`)
+	styleRegexp      = regexp.MustCompile(`(?is)<style\b.*?</style\s*>`)
+	whitespaceRegexp = regexp.MustCompile(`\s+`)
+)
+
+// htmlToPlainText strips an article's HTML down to plain text suitable for
+// snippet matching: script/style blocks are dropped, remaining tags are
+// stripped, entities are unescaped, and whitespace is collapsed.
+func htmlToPlainText(htmlBody string) string {
+	noScripts := scriptRegexp.ReplaceAllString(htmlBody, "")
+	noStyles := styleRegexp.ReplaceAllString(noScripts, "")
+	stripped := htmlTagRegex.ReplaceAllString(noStyles, " ")
+	unescaped := html.UnescapeString(stripped)
+	return strings.TrimSpace(whitespaceRegexp.ReplaceAllString(unescaped, " "))
+}
@@ -0,0 +1,283 @@
+// Package snippet builds highlighted search-result previews: given an
+// article's plain text and a search query, it locates where the query terms
+// occur and returns a short window of text around the best match with the
+// matched words wrapped in <mark> tags, plus per-term match metadata.
+package snippet
+
+import (
+	"html"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// MatchLevel describes how well a single query term matched the article text.
+type MatchLevel string
+
+const (
+	MatchFull    MatchLevel = "full"
+	MatchPartial MatchLevel = "partial"
+	MatchNone    MatchLevel = "none"
+)
+
+// Match describes how one query term fared against the article text.
+type Match struct {
+	Value        string     `json:"value"`
+	MatchLevel   MatchLevel `json:"match_level"`
+	MatchedWords []string   `json:"matched_words"`
+}
+
+// Result is the outcome of building a snippet for a query against a text.
+type Result struct {
+	Snippet string  `json:"snippet"`
+	Matches []Match `json:"matches"`
+}
+
+// DefaultLength is the snippet window size used when the caller does not
+// request a specific length. Lengths are counted in bytes of the UTF-8 text,
+// not in runes, so text with multi-byte characters gets fewer characters.
+const DefaultLength = 240
+
+const minStemLength = 3
+
+var wordRegexp = regexp.MustCompile(`[\p{L}\p{N}']+`)
+
+type wordSpan struct {
+	start, end int // byte offsets into the source text
+	text       string
+}
+
+type occurrence struct {
+	wordSpan
+	term  string
+	exact bool
+}
+
+// Build locates query within text and returns a highlighted snippet of
+// roughly length bytes (not runes), along with per-term match metadata. A
+// length <= 0 uses DefaultLength.
+func Build(text, query string, length int) Result {
+	if length <= 0 {
+		length = DefaultLength
+	}
+
+	terms := tokenize(query)
+	words := wordSpans(text)
+
+	occurrencesByTerm := make(map[string][]occurrence, len(terms))
+	var all []occurrence
+
+	for _, term := range terms {
+		stem := stem(term)
+		for _, w := range words {
+			lower := strings.ToLower(w.text)
+			switch {
+			case lower == term:
+				occ := occurrence{wordSpan: w, term: term, exact: true}
+				occurrencesByTerm[term] = append(occurrencesByTerm[term], occ)
+				all = append(all, occ)
+			case len(stem) >= minStemLength && strings.HasPrefix(lower, stem):
+				occ := occurrence{wordSpan: w, term: term, exact: false}
+				occurrencesByTerm[term] = append(occurrencesByTerm[term], occ)
+				all = append(all, occ)
+			}
+		}
+	}
+
+	start, end := window(text, all, length)
+
+	return Result{
+		Snippet: render(text, start, end, all),
+		Matches: matchesFor(terms, occurrencesByTerm),
+	}
+}
+
+// tokenize splits a query into unique, lowercased terms.
+func tokenize(query string) []string {
+	seen := make(map[string]bool)
+	var terms []string
+	for _, w := range wordRegexp.FindAllString(query, -1) {
+		lower := strings.ToLower(w)
+		if seen[lower] {
+			continue
+		}
+		seen[lower] = true
+		terms = append(terms, lower)
+	}
+	return terms
+}
+
+// wordSpans returns every word in text with its byte offsets.
+func wordSpans(text string) []wordSpan {
+	idxs := wordRegexp.FindAllStringIndex(text, -1)
+	spans := make([]wordSpan, 0, len(idxs))
+	for _, idx := range idxs {
+		spans = append(spans, wordSpan{start: idx[0], end: idx[1], text: text[idx[0]:idx[1]]})
+	}
+	return spans
+}
+
+// stem applies a light suffix-stripping heuristic so a query for "runs"
+// matches "run" and "running", without needing a full stemming library for
+// what is ultimately a highlighting aid.
+func stem(term string) string {
+	for _, suffix := range []string{"ing", "ied", "es", "ed", "s"} {
+		if strings.HasSuffix(term, suffix) && len(term)-len(suffix) >= minStemLength {
+			return term[:len(term)-len(suffix)]
+		}
+	}
+	return term
+}
+
+// window picks the start/end byte offsets of the snippet: the region with
+// the highest density of distinct matched terms, expanded to word
+// boundaries. If there are no occurrences, it falls back to the start of
+// the text.
+func window(text string, occurrences []occurrence, length int) (int, int) {
+	if len(occurrences) == 0 {
+		end := length
+		if end > len(text) {
+			end = len(text)
+		}
+		for end < len(text) && !isWordBoundary(text, end) {
+			end++
+		}
+		return 0, end
+	}
+
+	half := length / 2
+	bestStart, bestScore := occurrences[0].start, -1
+
+	for _, candidate := range occurrences {
+		lo, hi := candidate.start-half, candidate.start+half
+		distinctTerms := make(map[string]bool)
+		for _, other := range occurrences {
+			if other.start >= lo && other.start < hi {
+				distinctTerms[other.term] = true
+			}
+		}
+		if len(distinctTerms) > bestScore {
+			bestScore = len(distinctTerms)
+			bestStart = candidate.start
+		}
+	}
+
+	start := bestStart - half
+	if start < 0 {
+		start = 0
+	}
+	end := start + length
+	if end > len(text) {
+		end = len(text)
+		start = end - length
+		if start < 0 {
+			start = 0
+		}
+	}
+
+	// Expand to whole words so we never cut a match in half.
+	for start > 0 && !isWordBoundary(text, start) {
+		start--
+	}
+	for end < len(text) && !isWordBoundary(text, end) {
+		end++
+	}
+
+	return start, end
+}
+
+// isWordBoundary reports whether pos sits between a word rune and a
+// non-word rune (or at the start/end of text). It operates on whole runes
+// rather than raw bytes so it never mistakes the middle of a multi-byte
+// UTF-8 sequence for a boundary.
+func isWordBoundary(text string, pos int) bool {
+	if pos <= 0 || pos >= len(text) {
+		return true
+	}
+	if !utf8.RuneStart(text[pos]) {
+		return false
+	}
+	prev, _ := utf8.DecodeLastRuneInString(text[:pos])
+	next, _ := utf8.DecodeRuneInString(text[pos:])
+	return !isWordRune(prev) || !isWordRune(next)
+}
+
+func isWordRune(r rune) bool {
+	return wordRegexp.MatchString(string(r))
+}
+
+// render builds the final HTML-escaped snippet string, wrapping matched
+// words that fall inside [start, end) with <mark>...</mark> and adding
+// ellipses where the window was clipped.
+func render(text string, start, end int, occurrences []occurrence) string {
+	inWindow := make([]occurrence, 0, len(occurrences))
+	for _, occ := range occurrences {
+		if occ.start >= start && occ.end <= end {
+			inWindow = append(inWindow, occ)
+		}
+	}
+	sortByStart(inWindow)
+
+	var b strings.Builder
+	if start > 0 {
+		b.WriteString("…")
+	}
+
+	cursor := start
+	for _, occ := range inWindow {
+		if occ.start < cursor {
+			continue
+		}
+		b.WriteString(html.EscapeString(text[cursor:occ.start]))
+		b.WriteString("<mark>")
+		b.WriteString(html.EscapeString(text[occ.start:occ.end]))
+		b.WriteString("</mark>")
+		cursor = occ.end
+	}
+	b.WriteString(html.EscapeString(text[cursor:end]))
+
+	if end < len(text) {
+		b.WriteString("…")
+	}
+
+	return strings.TrimSpace(b.String())
+}
+
+func sortByStart(occs []occurrence) {
+	for i := 1; i < len(occs); i++ {
+		for j := i; j > 0 && occs[j].start < occs[j-1].start; j-- {
+			occs[j], occs[j-1] = occs[j-1], occs[j]
+		}
+	}
+}
+
+// matchesFor builds the per-term Match entries, in query order.
+func matchesFor(terms []string, byTerm map[string][]occurrence) []Match {
+	matches := make([]Match, 0, len(terms))
+	for _, term := range terms {
+		occs := byTerm[term]
+
+		level := MatchNone
+		seenWords := make(map[string]bool)
+		var words []string
+		for _, occ := range occs {
+			lower := strings.ToLower(occ.text)
+			if !seenWords[lower] {
+				seenWords[lower] = true
+				words = append(words, lower)
+			}
+			if occ.exact {
+				level = MatchFull
+			} else if level != MatchFull {
+				level = MatchPartial
+			}
+		}
+
+		matches = append(matches, Match{
+			Value:        term,
+			MatchLevel:   level,
+			MatchedWords: words,
+		})
+	}
+	return matches
+}
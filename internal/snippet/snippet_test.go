@@ -0,0 +1,84 @@
+package snippet
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestBuildHighlightsExactMatch(t *testing.T) {
+	text := "The quick brown fox jumps over the lazy dog near the old river bridge."
+	result := Build(text, "fox", 40)
+
+	if !strings.Contains(result.Snippet, "<mark>fox</mark>") {
+		t.Fatalf("expected snippet to highlight \"fox\", got: %q", result.Snippet)
+	}
+	if len(result.Matches) != 1 {
+		t.Fatalf("expected 1 match entry, got %d", len(result.Matches))
+	}
+	if result.Matches[0].MatchLevel != MatchFull {
+		t.Errorf("expected full match level, got %q", result.Matches[0].MatchLevel)
+	}
+	if got := result.Matches[0].MatchedWords; len(got) != 1 || got[0] != "fox" {
+		t.Errorf("expected matchedWords [fox], got %v", got)
+	}
+}
+
+func TestBuildPartialMatchViaStemming(t *testing.T) {
+	text := "Runners love running marathons every weekend in the park."
+	result := Build(text, "run", 60)
+
+	if result.Matches[0].MatchLevel != MatchPartial {
+		t.Errorf("expected partial match level, got %q", result.Matches[0].MatchLevel)
+	}
+	if !strings.Contains(result.Snippet, "<mark>") {
+		t.Errorf("expected snippet to highlight a stemmed match, got: %q", result.Snippet)
+	}
+}
+
+func TestBuildNoMatch(t *testing.T) {
+	text := "An entirely unrelated sentence about gardening."
+	result := Build(text, "submarine", 40)
+
+	if result.Matches[0].MatchLevel != MatchNone {
+		t.Errorf("expected no match level, got %q", result.Matches[0].MatchLevel)
+	}
+	if len(result.Matches[0].MatchedWords) != 0 {
+		t.Errorf("expected no matched words, got %v", result.Matches[0].MatchedWords)
+	}
+	if strings.Contains(result.Snippet, "<mark>") {
+		t.Errorf("expected no highlight in snippet, got: %q", result.Snippet)
+	}
+}
+
+func TestBuildEscapesHTML(t *testing.T) {
+	text := "Tom & Jerry <fight> over fox ownership in the barn."
+	result := Build(text, "fox", 40)
+
+	if strings.Contains(result.Snippet, "<fight>") {
+		t.Errorf("expected raw HTML to be escaped, got: %q", result.Snippet)
+	}
+	if !strings.Contains(result.Snippet, "&lt;fight&gt;") {
+		t.Errorf("expected <fight> to be escaped, got: %q", result.Snippet)
+	}
+}
+
+func TestBuildMultiByteTextStaysValidUTF8(t *testing.T) {
+	text := strings.Repeat("東京は日本の首都である。", 20) + "今日は東京で桜を見た。" + strings.Repeat("大阪は日本の都市である。", 20)
+
+	for length := 10; length <= 120; length += 5 {
+		result := Build(text, "東京", length)
+		if !utf8.ValidString(result.Snippet) {
+			t.Fatalf("length=%d: snippet is not valid UTF-8: %q", length, result.Snippet)
+		}
+	}
+}
+
+func TestBuildWindowsAroundBestCluster(t *testing.T) {
+	text := strings.Repeat("filler ", 40) + "the quick fox near the river and the fox again" + strings.Repeat(" filler", 40)
+	result := Build(text, "fox river", 60)
+
+	if !strings.Contains(result.Snippet, "<mark>fox</mark>") || !strings.Contains(result.Snippet, "<mark>river</mark>") {
+		t.Errorf("expected snippet window to contain both matched terms, got: %q", result.Snippet)
+	}
+}
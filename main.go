@@ -6,24 +6,31 @@ import (
 	"fmt"
 	"html"
 	"log"
+	"net/http"
 	"os"
 	"regexp"
+	"sort"
 	"strings"
+	"sync"
 
 	"github.com/JohannesKaufmann/html-to-markdown/v2/converter"
 	"github.com/JohannesKaufmann/html-to-markdown/v2/plugin/base"
 	"github.com/JohannesKaufmann/html-to-markdown/v2/plugin/commonmark"
 
 	"github.com/akhenakh/zim-cgo/zim"
+	"github.com/akhenakh/zim-mcp/internal/snippet"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 )
 
 // SearchResultItem defines the structured JSON format for a single search match
 type SearchResultItem struct {
-	Title string `json:"title"`
-	Path  string `json:"path"`
-	Score int    `json:"score"`
+	Archive string          `json:"archive"`
+	Title   string          `json:"title"`
+	Path    string          `json:"path"`
+	Score   int             `json:"score"`
+	Snippet string          `json:"snippet,omitempty"`
+	Matches []snippet.Match `json:"matches,omitempty"`
 }
 
 // SearchResponse defines the JSON envelope for the search endpoint
@@ -36,27 +43,94 @@ type ReadResponse struct {
 	Markdown string `json:"markdown"`
 }
 
+// ListArchivesResponse defines the JSON envelope for the list_archives tool
+type ListArchivesResponse struct {
+	Archives []ArchiveInfo `json:"archives"`
+}
+
 // Compile regex once globally to strip HTML tags from titles
 var htmlTagRegex = regexp.MustCompile(`<[^>]*>`)
 
+// Compiled once globally since read_article runs these over every converted
+// article; re-compiling per request was the hottest part of that path.
+var (
+	markdownLinkRegex  = regexp.MustCompile(`(?s)\[(.*?)\]\((.*?)\)`)
+	excessNewlineRegex = regexp.MustCompile(`\n{3,}`)
+)
+
+// cleanArticleMarkdown strips empty links and internal/relative navigation
+// links left behind by the HTML-to-Markdown conversion, and collapses the
+// resulting blank lines.
+func cleanArticleMarkdown(md string) string {
+	md = markdownLinkRegex.ReplaceAllStringFunc(md, func(match string) string {
+		subs := markdownLinkRegex.FindStringSubmatch(match)
+		if len(subs) != 3 {
+			return match
+		}
+
+		text := subs[1]
+		href := subs[2]
+
+		// If text is empty (e.g., from a removed image), drop the link entirely to avoid []()
+		if strings.TrimSpace(text) == "" {
+			return ""
+		}
+
+		// Extract the actual URL (ignoring markdown titles like: url "title")
+		urlPart := strings.TrimSpace(strings.Split(href, " ")[0])
+
+		// If it's a relative/internal link (no http/https), strip the markdown link format and just return the inner text
+		if !strings.HasPrefix(urlPart, "http://") && !strings.HasPrefix(urlPart, "https://") {
+			return text
+		}
+
+		// Otherwise, it's an external link; keep it completely intact
+		return match
+	})
+
+	// Clean up any excessive newlines left behind by removed empty links
+	return excessNewlineRegex.ReplaceAllString(md, "\n\n")
+}
+
 func main() {
 	// Parse flags
-	zimPath := flag.String("z", "", "Path to the .zim file")
+	var zimPaths multiFlag
+	flag.Var(&zimPaths, "z", "Path to a .zim file. May be repeated to load several archives.")
+	zimDir := flag.String("zdir", "", "Directory containing .zim files to load (non-recursive).")
 	listenAddr := flag.String("listen", "", "Listen address for HTTP/SSE server (e.g., :4545). If empty, uses stdio.")
+	cacheArticles := flag.Int("cache-articles", 512, "Number of converted articles to keep in the read_article LRU cache.")
+	cacheSearches := flag.Int("cache-searches", 128, "Number of search_zim responses to keep in the search LRU cache.")
+	maxRawBytes := flag.Int("max-raw-bytes", 4*1024*1024, "Maximum size in bytes that read_raw will return in a single response.")
+	httpBrowse := flag.Bool("http-browse", false, "When set alongside -listen, also mount a read-only HTTP browse surface (GET /archives, /{archive}/A/{path}, /{archive}/search) on the same listener.")
 	flag.Parse()
 
-	if *zimPath == "" {
-		fmt.Fprintln(os.Stderr, "Error: You must provide a path to a .zim file.")
-		fmt.Fprintln(os.Stderr, "Usage: mcp-zim -z <path-to-zim-file> [-listen :4545]")
+	paths, err := collectZimPaths(zimPaths, *zimDir)
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+
+	if len(paths) == 0 {
+		fmt.Fprintln(os.Stderr, "Error: You must provide at least one path to a .zim file.")
+		fmt.Fprintln(os.Stderr, "Usage: mcp-zim -z <path-to-zim-file> [-z <path-to-another.zim>] [-zdir <dir>] [-listen :4545]")
 		os.Exit(1)
 	}
 
-	// Open the ZIM archive
-	archive, err := zim.NewArchive(*zimPath)
+	caches, err := NewCaches(*cacheArticles, *cacheSearches)
 	if err != nil {
-		log.Fatalf("Failed to open zim archive at %s: %v", *zimPath, err)
+		log.Fatalf("Failed to initialize caches: %v", err)
+	}
+
+	// Open every ZIM archive and register it by name
+	registry := NewArchiveRegistry()
+	defer registry.Close()
+
+	for _, p := range paths {
+		loaded, err := registry.Add(p)
+		if err != nil {
+			log.Fatalf("%v", err)
+		}
+		log.Printf("Loaded archive %q from %s", loaded.Name, loaded.Path)
 	}
-	defer archive.Close()
 
 	// Configure the HTML to Markdown Converter
 	conv := converter.NewConverter(
@@ -81,7 +155,7 @@ func main() {
 
 	// Tool: search_zim
 	searchTool := mcp.NewTool("search_zim",
-		mcp.WithDescription("Search the offline ZIM archive for articles. Returns a structured JSON array of top hits with their Title, Path, and Score."),
+		mcp.WithDescription("Search the offline ZIM archive(s) for articles. Returns a structured JSON array of top hits with their Archive, Title, Path, Score, and (when highlight is enabled) a highlighted Snippet plus per-term Matches."),
 		mcp.WithString("query",
 			mcp.Required(),
 			mcp.Description("The keyword or phrase to search for."),
@@ -89,13 +163,18 @@ func main() {
 		mcp.WithNumber("count",
 			mcp.Description("Number of results to return. Defaults to 20."),
 		),
+		mcp.WithString("archive",
+			mcp.Description("Restrict the search to a single archive name (as returned by list_archives). Searches all loaded archives when omitted."),
+		),
+		mcp.WithBoolean("highlight",
+			mcp.Description("Whether to include a highlighted snippet and per-term match metadata for each hit. Defaults to true."),
+		),
+		mcp.WithNumber("snippet_length",
+			mcp.Description("Approximate length in bytes of the highlighted snippet (equal to characters for ASCII text). Defaults to 240."),
+		),
 	)
 
 	s.AddTool(searchTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		if !archive.HasFulltextIndex() {
-			return mcp.NewToolResultError("This ZIM file does not contain a full-text search index."), nil
-		}
-
 		queryStr, err := request.RequireString("query")
 		if err != nil {
 			return mcp.NewToolResultError(err.Error()), nil
@@ -103,49 +182,63 @@ func main() {
 
 		// Use requested count
 		count := request.GetInt("count", 20)
-
-		searcher, err := zim.NewSearcher(archive)
-		if err != nil {
-			return mcp.NewToolResultError("Failed to initialize searcher: " + err.Error()), nil
+		if count <= 0 {
+			count = 20
 		}
-		defer searcher.Close()
-
-		q, err := zim.NewQuery(queryStr)
-		if err != nil {
-			return mcp.NewToolResultError("Failed to parse query: " + err.Error()), nil
+		highlight := request.GetBool("highlight", true)
+		snippetLength := request.GetInt("snippet_length", snippet.DefaultLength)
+
+		targets := registry.All()
+		if archiveName := request.GetString("archive", ""); archiveName != "" {
+			loaded, ok := registry.Get(archiveName)
+			if !ok {
+				return mcp.NewToolResultError(fmt.Sprintf("Unknown archive %q. Call list_archives to see what is loaded.", archiveName)), nil
+			}
+			targets = []*LoadedArchive{loaded}
 		}
-		defer q.Close()
 
-		search, err := searcher.Search(q)
-		if err != nil {
-			return mcp.NewToolResultError("Search execution failed: " + err.Error()), nil
+		var (
+			mu      sync.Mutex
+			wg      sync.WaitGroup
+			all     []SearchResultItem
+			indexed bool
+		)
+
+		for _, loaded := range targets {
+			if !loaded.Archive.HasFulltextIndex() {
+				continue
+			}
+			indexed = true
+
+			wg.Add(1)
+			go func(loaded *LoadedArchive) {
+				defer wg.Done()
+
+				items, err := searchArchive(caches, loaded, queryStr, count, highlight, snippetLength)
+				if err != nil {
+					log.Printf("search_zim: archive %q: %v", loaded.Name, err)
+					return
+				}
+
+				mu.Lock()
+				all = append(all, items...)
+				mu.Unlock()
+			}(loaded)
 		}
-		defer search.Close()
+		wg.Wait()
 
-		results, err := search.GetResults(0, count)
-		if err != nil {
-			return mcp.NewToolResultError("Failed to retrieve results: " + err.Error()), nil
+		if !indexed {
+			return mcp.NewToolResultError("No targeted archive contains a full-text search index."), nil
 		}
 
-		// Prepare the JSON response container
-		var respData SearchResponse
+		sort.SliceStable(all, func(i, j int) bool { return all[i].Score > all[j].Score })
+		if len(all) > count {
+			all = all[:count]
+		}
 
-		if len(results) == 0 {
+		respData := SearchResponse{Results: all}
+		if respData.Results == nil {
 			respData.Results = []SearchResultItem{}
-		} else {
-			respData.Results = make([]SearchResultItem, 0, len(results))
-			for _, res := range results {
-				// Clean the title: Unescape HTML entities (e.g., &lt; -> <) then strip tags
-				cleanTitle := html.UnescapeString(res.Title)
-				cleanTitle = htmlTagRegex.ReplaceAllString(cleanTitle, "")
-				cleanTitle = strings.TrimSpace(cleanTitle)
-
-				respData.Results = append(respData.Results, SearchResultItem{
-					Title: cleanTitle,
-					Path:  res.Path,
-					Score: res.Score,
-				})
-			}
 		}
 
 		// Use mcp.NewToolResultJSON to wrap the struct in a JSON envelope automatically
@@ -158,10 +251,13 @@ func main() {
 
 	// Tool: read_article
 	readTool := mcp.NewTool("read_article",
-		mcp.WithDescription("Read an article from the ZIM archive using its exact Path. The HTML is converted to Markdown and returned within a JSON envelope."),
+		mcp.WithDescription("Read an article from a ZIM archive using its exact Path. The HTML is converted to Markdown and returned within a JSON envelope. When several archives are loaded, identify the article either with a compound \"archive:path\" in the path argument or with the separate archive argument."),
 		mcp.WithString("path",
 			mcp.Required(),
-			mcp.Description("The exact Path of the article (obtained from search_zim)."),
+			mcp.Description("The exact Path of the article (obtained from search_zim), optionally prefixed with \"archive:\"."),
+		),
+		mcp.WithString("archive",
+			mcp.Description("The archive name to read from (as returned by list_archives). Only needed when path is not already archive-qualified and more than one archive is loaded."),
 		),
 	)
 
@@ -171,90 +267,214 @@ func main() {
 			return mcp.NewToolResultError(err.Error()), nil
 		}
 
-		entry, err := archive.GetEntryByPath(path)
+		loaded, entryPath, err := resolveArchiveAndPath(registry, path, request.GetString("archive", ""))
 		if err != nil {
-			return mcp.NewToolResultError(fmt.Sprintf("Article not found for path '%s': %v", path, err)), nil
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		articleKey := articleCacheKey{archivePath: loaded.Path, entryPath: entryPath}
+		finalMarkdown, ok := caches.GetArticle(articleKey)
+		if !ok {
+			entry, err := loaded.Archive.GetEntryByPath(entryPath)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Article not found for path '%s' in archive %q: %v", entryPath, loaded.Name, err)), nil
+			}
+			defer entry.Close()
+
+			// GetItem with follow redirects set to true
+			item, err := entry.GetItem(true)
+			if err != nil {
+				return mcp.NewToolResultError("Failed to load article item: " + err.Error()), nil
+			}
+			defer item.Close()
+
+			mime := item.GetMimetype()
+			data := item.GetData()
+
+			switch mime {
+			case "text/html":
+				md, err := conv.ConvertString(string(data))
+				if err != nil {
+					return mcp.NewToolResultError("Failed to convert HTML to Markdown: " + err.Error()), nil
+				}
+				finalMarkdown = cleanArticleMarkdown(md)
+			case "text/plain":
+				// If it's a plain text file, return it directly
+				finalMarkdown = string(data)
+			default:
+				// Exclude images, videos, binary blobs, etc.
+				return mcp.NewToolResultError(fmt.Sprintf("Cannot read non-text article (mimetype: %s)", mime)), nil
+			}
+
+			caches.PutArticle(articleKey, finalMarkdown)
+		}
+
+		// Put it in the JSON envelope
+		res, err := mcp.NewToolResultJSON(ReadResponse{
+			Markdown: finalMarkdown,
+		})
+		if err != nil {
+			return mcp.NewToolResultError("Failed to serialize JSON envelope: " + err.Error()), nil
+		}
+
+		return res, nil
+	})
+
+	// Tool: read_raw
+	readRawTool := mcp.NewTool("read_raw",
+		mcp.WithDescription("Read the raw, non-HTML bytes of a ZIM entry (images, PDFs, and other embedded media) as base64, along with its mimetype, size, and (for decodable images) pixel dimensions."),
+		mcp.WithString("path",
+			mcp.Required(),
+			mcp.Description("The exact Path of the entry, optionally prefixed with \"archive:\"."),
+		),
+		mcp.WithString("archive",
+			mcp.Description("The archive name to read from (as returned by list_archives). Only needed when path is not already archive-qualified and more than one archive is loaded."),
+		),
+	)
+
+	s.AddTool(readRawTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		path, err := request.RequireString("path")
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		loaded, entryPath, err := resolveArchiveAndPath(registry, path, request.GetString("archive", ""))
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		entry, err := loaded.Archive.GetEntryByPath(entryPath)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Entry not found for path '%s' in archive %q: %v", entryPath, loaded.Name, err)), nil
 		}
 		defer entry.Close()
 
-		// GetItem with follow redirects set to true
+		// GetItem with follow redirects set to true, same as read_article
 		item, err := entry.GetItem(true)
 		if err != nil {
-			return mcp.NewToolResultError("Failed to load article item: " + err.Error()), nil
+			return mcp.NewToolResultError("Failed to load entry item: " + err.Error()), nil
 		}
 		defer item.Close()
 
-		mime := item.GetMimetype()
-		data := item.GetData()
-
-		var finalMarkdown string
-
-		// Process HTML using our customized html-to-markdown converter
-		switch mime {
-		case "text/html":
-			md, err := conv.ConvertString(string(data))
-			if err != nil {
-				return mcp.NewToolResultError("Failed to convert HTML to Markdown: " + err.Error()), nil
-			}
+		rawResp, err := buildRawEntryResponse(item.GetMimetype(), item.GetData(), *maxRawBytes)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
 
-			// Post-process the Markdown to remove empty links and internal/relative navigation links
-			// (?s) allows non-greedy matching across newlines if a link text happens to wrap
-			linkRegex := regexp.MustCompile(`(?s)\[(.*?)\]\((.*?)\)`)
+		res, err := mcp.NewToolResultJSON(rawResp)
+		if err != nil {
+			return mcp.NewToolResultError("Failed to serialize JSON envelope: " + err.Error()), nil
+		}
+		return res, nil
+	})
 
-			md = linkRegex.ReplaceAllStringFunc(md, func(match string) string {
-				subs := linkRegex.FindStringSubmatch(match)
-				if len(subs) != 3 {
-					return match
-				}
+	// Tool: suggest_zim
+	suggestTool := mcp.NewTool("suggest_zim",
+		mcp.WithDescription("Autocomplete an article title against a ZIM archive's title suggestion index. Much faster than search_zim and intended for resolving a name (e.g. \"Einst\") to an exact path before calling read_article."),
+		mcp.WithString("query",
+			mcp.Required(),
+			mcp.Description("The title prefix or fragment to autocomplete."),
+		),
+		mcp.WithNumber("count",
+			mcp.Description("Number of suggestions to return. Defaults to 10."),
+		),
+		mcp.WithString("archive",
+			mcp.Description("Restrict the suggestion lookup to a single archive name (as returned by list_archives). Queries all loaded archives when omitted."),
+		),
+	)
 
-				text := subs[1]
-				href := subs[2]
+	s.AddTool(suggestTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		queryStr, err := request.RequireString("query")
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		count := request.GetInt("count", 10)
+		if count <= 0 {
+			count = 10
+		}
 
-				// If text is empty (e.g., from a removed image), drop the link entirely to avoid []()
-				if strings.TrimSpace(text) == "" {
-					return ""
-				}
+		targets := registry.All()
+		if archiveName := request.GetString("archive", ""); archiveName != "" {
+			loaded, ok := registry.Get(archiveName)
+			if !ok {
+				return mcp.NewToolResultError(fmt.Sprintf("Unknown archive %q. Call list_archives to see what is loaded.", archiveName)), nil
+			}
+			targets = []*LoadedArchive{loaded}
+		}
 
-				// Extract the actual URL (ignoring markdown titles like: url "title")
-				urlPart := strings.TrimSpace(strings.Split(href, " ")[0])
+		var all []SuggestResultItem
+		var lastErr error
+		for _, loaded := range targets {
+			items, err := suggestArchive(loaded, queryStr, count)
+			if err != nil {
+				lastErr = err
+				continue
+			}
+			all = append(all, items...)
+		}
 
-				// If it's a relative/internal link (no http/https), strip the markdown link format and just return the inner text
-				if !strings.HasPrefix(urlPart, "http://") && !strings.HasPrefix(urlPart, "https://") {
-					return text
-				}
+		if all == nil {
+			if lastErr != nil {
+				return mcp.NewToolResultError(lastErr.Error()), nil
+			}
+			all = []SuggestResultItem{}
+		}
+		if len(all) > count {
+			all = all[:count]
+		}
 
-				// Otherwise, it's an external link; keep it completely intact
-				return match
-			})
+		res, err := mcp.NewToolResultJSON(SuggestResponse{Results: all})
+		if err != nil {
+			return mcp.NewToolResultError("Failed to serialize JSON envelope: " + err.Error()), nil
+		}
+		return res, nil
+	})
 
-			// Clean up any excessive newlines left behind by removed empty links
-			newlineRegex := regexp.MustCompile(`\n{3,}`)
-			md = newlineRegex.ReplaceAllString(md, "\n\n")
+	// Tool: list_archives
+	listArchivesTool := mcp.NewTool("list_archives",
+		mcp.WithDescription("List every ZIM archive currently loaded by this server, with its title, description, article count, language, and whether it has a full-text search index."),
+	)
 
-			finalMarkdown = md
-		case "text/plain":
-			// If it's a plain text file, return it directly
-			finalMarkdown = string(data)
-		default:
-			// Exclude images, videos, binary blobs, etc.
-			return mcp.NewToolResultError(fmt.Sprintf("Cannot read non-text article (mimetype: %s)", mime)), nil
+	s.AddTool(listArchivesTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		loadedArchives := registry.All()
+		infos := make([]ArchiveInfo, 0, len(loadedArchives))
+		for _, loaded := range loadedArchives {
+			infos = append(infos, describeArchive(loaded))
 		}
 
-		// Put it in the JSON envelope
-		res, err := mcp.NewToolResultJSON(ReadResponse{
-			Markdown: finalMarkdown,
-		})
+		res, err := mcp.NewToolResultJSON(ListArchivesResponse{Archives: infos})
 		if err != nil {
 			return mcp.NewToolResultError("Failed to serialize JSON envelope: " + err.Error()), nil
 		}
+		return res, nil
+	})
 
+	// Tool: cache_stats
+	cacheStatsTool := mcp.NewTool("cache_stats",
+		mcp.WithDescription("Report hit/miss/eviction counters for the read_article and search_zim in-process caches."),
+	)
+
+	s.AddTool(cacheStatsTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		res, err := mcp.NewToolResultJSON(caches.Stats())
+		if err != nil {
+			return mcp.NewToolResultError("Failed to serialize JSON envelope: " + err.Error()), nil
+		}
 		return res, nil
 	})
 
 	// Start the server (HTTP or Stdio)
 	if *listenAddr != "" {
 		log.Printf("Starting MCP server on %s (HTTP/SSE)", *listenAddr)
-		httpServer := server.NewStreamableHTTPServer(s)
+
+		mux := http.NewServeMux()
+		if *httpBrowse {
+			log.Printf("Mounting read-only HTTP browse surface alongside the MCP endpoint")
+			mountBrowseRoutes(mux, registry, caches)
+		}
+
+		httpServer := server.NewStreamableHTTPServer(s, server.WithStreamableHTTPServer(&http.Server{Handler: mux}))
+		mux.Handle("/mcp", httpServer)
+
 		if err := httpServer.Start(*listenAddr); err != nil {
 			log.Fatalf("HTTP Server error: %v", err)
 		}
@@ -265,3 +485,123 @@ func main() {
 		}
 	}
 }
+
+// resolveArchiveAndPath determines which loaded archive and entry path a
+// read_article (or read_raw) request refers to, supporting either a compound
+// "archive:path" identifier or a separate archive name.
+func resolveArchiveAndPath(registry *ArchiveRegistry, path, archiveArg string) (*LoadedArchive, string, error) {
+	if archiveArg != "" {
+		loaded, ok := registry.Get(archiveArg)
+		if !ok {
+			return nil, "", fmt.Errorf("unknown archive %q. Call list_archives to see what is loaded", archiveArg)
+		}
+		return loaded, path, nil
+	}
+
+	if name, rest, ok := splitArchiveQualifiedPath(path); ok {
+		if loaded, found := registry.Get(name); found {
+			return loaded, rest, nil
+		}
+	}
+
+	if loaded, ok := registry.Only(); ok {
+		return loaded, path, nil
+	}
+
+	return nil, "", fmt.Errorf("multiple archives are loaded; qualify the path as \"archive:path\" or pass the archive argument")
+}
+
+// searchArchive runs a full-text query against a single archive and converts
+// the matches into the tool's SearchResultItem DTO, consulting the search
+// cache first.
+func searchArchive(caches *Caches, loaded *LoadedArchive, queryStr string, count int, highlight bool, snippetLength int) ([]SearchResultItem, error) {
+	cacheKey := searchCacheKey{
+		archivePath:   loaded.Path,
+		query:         queryStr,
+		count:         count,
+		highlight:     highlight,
+		snippetLength: snippetLength,
+	}
+	if cached, ok := caches.GetSearch(cacheKey); ok {
+		return cached, nil
+	}
+
+	searcher, err := zim.NewSearcher(loaded.Archive)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize searcher: %w", err)
+	}
+	defer searcher.Close()
+
+	q, err := zim.NewQuery(queryStr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse query: %w", err)
+	}
+	defer q.Close()
+
+	search, err := searcher.Search(q)
+	if err != nil {
+		return nil, fmt.Errorf("search execution failed: %w", err)
+	}
+	defer search.Close()
+
+	results, err := search.GetResults(0, count)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve results: %w", err)
+	}
+
+	items := make([]SearchResultItem, 0, len(results))
+	for _, res := range results {
+		// Clean the title: Unescape HTML entities (e.g., &lt; -> <) then strip tags
+		cleanTitle := html.UnescapeString(res.Title)
+		cleanTitle = htmlTagRegex.ReplaceAllString(cleanTitle, "")
+		cleanTitle = strings.TrimSpace(cleanTitle)
+
+		item := SearchResultItem{
+			Archive: loaded.Name,
+			Title:   cleanTitle,
+			Path:    res.Path,
+			Score:   res.Score,
+		}
+
+		if highlight {
+			snip, matches := buildSnippet(loaded, res.Path, queryStr, snippetLength)
+			item.Snippet = snip
+			item.Matches = matches
+		}
+
+		items = append(items, item)
+	}
+
+	caches.PutSearch(cacheKey, items)
+	return items, nil
+}
+
+// buildSnippet loads the given entry's body and produces a highlighted
+// snippet for queryStr. Failures to load the entry (e.g. a non-text result)
+// simply yield no snippet rather than failing the whole search.
+func buildSnippet(loaded *LoadedArchive, path, queryStr string, snippetLength int) (string, []snippet.Match) {
+	entry, err := loaded.Archive.GetEntryByPath(path)
+	if err != nil {
+		return "", nil
+	}
+	defer entry.Close()
+
+	item, err := entry.GetItem(true)
+	if err != nil {
+		return "", nil
+	}
+	defer item.Close()
+
+	var text string
+	switch item.GetMimetype() {
+	case "text/html":
+		text = htmlToPlainText(string(item.GetData()))
+	case "text/plain":
+		text = string(item.GetData())
+	default:
+		return "", nil
+	}
+
+	result := snippet.Build(text, queryStr, snippetLength)
+	return result.Snippet, result.Matches
+}
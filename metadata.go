@@ -0,0 +1,46 @@
+package main
+
+import "github.com/akhenakh/zim-cgo/zim"
+
+// ArchiveInfo describes a loaded archive's metadata for the list_archives tool.
+type ArchiveInfo struct {
+	Name             string `json:"name"`
+	Title            string `json:"title"`
+	Description      string `json:"description"`
+	Language         string `json:"language"`
+	ArticleCount     uint64 `json:"article_count"`
+	HasFulltextIndex bool   `json:"has_fulltext_index"`
+}
+
+// readMetadataEntry reads a ZIM metadata value by name. libzim stores
+// metadata (Title, Description, Language, ...) as regular entries under the
+// "M/" namespace, so this cgo wrapper's API exposes it the same way a normal
+// article is read rather than through a dedicated metadata accessor.
+func readMetadataEntry(archive *zim.Archive, name string) string {
+	entry, err := archive.GetEntryByPath("M/" + name)
+	if err != nil {
+		return ""
+	}
+	defer entry.Close()
+
+	item, err := entry.GetItem(false)
+	if err != nil {
+		return ""
+	}
+	defer item.Close()
+
+	return string(item.GetData())
+}
+
+// describeArchive builds the ArchiveInfo summary for a loaded archive.
+func describeArchive(loaded *LoadedArchive) ArchiveInfo {
+	a := loaded.Archive
+	return ArchiveInfo{
+		Name:             loaded.Name,
+		Title:            readMetadataEntry(a, "Title"),
+		Description:      readMetadataEntry(a, "Description"),
+		Language:         readMetadataEntry(a, "Language"),
+		ArticleCount:     a.GetEntryCount(),
+		HasFulltextIndex: a.HasFulltextIndex(),
+	}
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"strings"
+)
+
+// RawEntryResponse is the JSON envelope for the read_raw tool.
+type RawEntryResponse struct {
+	Mimetype string `json:"mimetype"`
+	Size     int    `json:"size"`
+	Data     string `json:"data"`
+	Width    int    `json:"width,omitempty"`
+	Height   int    `json:"height,omitempty"`
+}
+
+// buildRawEntryResponse base64-encodes an entry's raw bytes and, for image
+// mimetypes the standard library can decode, fills in its pixel dimensions.
+// It returns a structured error rather than the response if data exceeds
+// maxRawBytes, so the JSON-RPC channel is never flooded with an oversized
+// payload.
+func buildRawEntryResponse(mimetype string, data []byte, maxRawBytes int) (*RawEntryResponse, error) {
+	if len(data) > maxRawBytes {
+		return nil, fmt.Errorf("entry is %d bytes, exceeding the max-raw-bytes limit of %d", len(data), maxRawBytes)
+	}
+
+	resp := &RawEntryResponse{
+		Mimetype: mimetype,
+		Size:     len(data),
+		Data:     base64.StdEncoding.EncodeToString(data),
+	}
+
+	if strings.HasPrefix(mimetype, "image/") {
+		if cfg, _, err := image.DecodeConfig(bytes.NewReader(data)); err == nil {
+			resp.Width = cfg.Width
+			resp.Height = cfg.Height
+		}
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+)
+
+func TestBuildRawEntryResponseSizeCap(t *testing.T) {
+	cases := []struct {
+		name        string
+		data        []byte
+		maxRawBytes int
+		wantErr     bool
+	}{
+		{"under the cap", make([]byte, 10), 100, false},
+		{"exactly at the cap", make([]byte, 100), 100, false},
+		{"over the cap", make([]byte, 101), 100, true},
+		{"zero-length data never exceeds", nil, 0, false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			resp, err := buildRawEntryResponse("application/octet-stream", tc.data, tc.maxRawBytes)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error for %d bytes against a %d-byte cap, got none", len(tc.data), tc.maxRawBytes)
+				}
+				if resp != nil {
+					t.Errorf("expected nil response alongside the error, got %+v", resp)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if resp.Size != len(tc.data) {
+				t.Errorf("Size = %d, want %d", resp.Size, len(tc.data))
+			}
+			if resp.Data != base64.StdEncoding.EncodeToString(tc.data) {
+				t.Errorf("Data was not base64-encoded correctly")
+			}
+		})
+	}
+}
+
+func TestBuildRawEntryResponseImageDimensions(t *testing.T) {
+	// A minimal valid 1x1 GIF, the smallest image format the stdlib decodes
+	// without extra dependencies.
+	gif1x1 := []byte{
+		0x47, 0x49, 0x46, 0x38, 0x39, 0x61, // GIF89a
+		0x01, 0x00, 0x01, 0x00, // 1x1
+		0x80, 0x00, 0x00, // flags, background, aspect ratio
+		0xff, 0xff, 0xff, 0x00, 0x00, 0x00, // color table
+		0x21, 0xf9, 0x04, 0x00, 0x00, 0x00, 0x00, 0x00, // graphic control extension
+		0x2c, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x01, 0x00, 0x00, // image descriptor
+		0x02, 0x02, 0x44, 0x01, 0x00, // image data
+		0x3b, // trailer
+	}
+
+	resp, err := buildRawEntryResponse("image/gif", gif1x1, len(gif1x1)+1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.Width != 1 || resp.Height != 1 {
+		t.Errorf("expected 1x1 image dimensions, got %dx%d", resp.Width, resp.Height)
+	}
+
+	respNonImage, err := buildRawEntryResponse("application/pdf", []byte("%PDF-1.4"), 100)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if respNonImage.Width != 0 || respNonImage.Height != 0 {
+		t.Errorf("expected no dimensions for a non-image mimetype, got %dx%d", respNonImage.Width, respNonImage.Height)
+	}
+	if !strings.HasPrefix(respNonImage.Mimetype, "application/") {
+		t.Errorf("unexpected mimetype: %q", respNonImage.Mimetype)
+	}
+}
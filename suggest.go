@@ -0,0 +1,69 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/akhenakh/zim-cgo/zim"
+)
+
+// SuggestResultItem is a single title-autocomplete hit for the suggest_zim tool.
+type SuggestResultItem struct {
+	Archive       string `json:"archive"`
+	Title         string `json:"title"`
+	Path          string `json:"path"`
+	MatchedPrefix string `json:"matched_prefix"`
+	MatchLevel    string `json:"match_level"`
+}
+
+// SuggestResponse is the JSON envelope for the suggest_zim tool.
+type SuggestResponse struct {
+	Results []SuggestResultItem `json:"results"`
+}
+
+// suggestArchive runs a title-suggestion query against a single archive's
+// suggestion (title) index, which is distinct from and much faster than the
+// full-text index used by search_zim.
+func suggestArchive(loaded *LoadedArchive, query string, count int) ([]SuggestResultItem, error) {
+	searcher, err := zim.NewSuggestionSearcher(loaded.Archive)
+	if err != nil {
+		return nil, fmt.Errorf("archive %q does not have a title suggestion index: %w", loaded.Name, err)
+	}
+	defer searcher.Close()
+
+	search, err := searcher.Suggest(query)
+	if err != nil {
+		return nil, fmt.Errorf("suggestion search failed: %w", err)
+	}
+	defer search.Close()
+
+	results, err := search.GetResults(0, count)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve suggestions: %w", err)
+	}
+
+	items := make([]SuggestResultItem, 0, len(results))
+	for _, res := range results {
+		prefix, level := matchedPrefix(res.Title, query)
+		items = append(items, SuggestResultItem{
+			Archive:       loaded.Name,
+			Title:         res.Title,
+			Path:          res.Path,
+			MatchedPrefix: prefix,
+			MatchLevel:    level,
+		})
+	}
+	return items, nil
+}
+
+// matchedPrefix reports how query relates to title: a "full" match when
+// title starts with query (case-insensitive), in which case the matched
+// prefix is the corresponding slice of title's own casing, otherwise a
+// "partial" match where the suggestion index found title via some other
+// relation (e.g. a mid-title token) and query itself is reported back.
+func matchedPrefix(title, query string) (prefix, level string) {
+	if len(query) <= len(title) && strings.EqualFold(title[:len(query)], query) {
+		return title[:len(query)], "full"
+	}
+	return query, "partial"
+}
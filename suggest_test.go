@@ -0,0 +1,30 @@
+package main
+
+import "testing"
+
+func TestMatchedPrefix(t *testing.T) {
+	cases := []struct {
+		name       string
+		title      string
+		query      string
+		wantPrefix string
+		wantLevel  string
+	}{
+		{"exact prefix", "Tokyo", "Tok", "Tok", "full"},
+		{"case-insensitive prefix", "Tokyo", "tok", "Tok", "full"},
+		{"full title match", "Tokyo", "Tokyo", "Tokyo", "full"},
+		{"mid-title token", "Greater Tokyo Area", "Tokyo", "Tokyo", "partial"},
+		{"query longer than title", "Edo", "Edozuke", "Edozuke", "partial"},
+		{"empty query", "Tokyo", "", "", "full"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			prefix, level := matchedPrefix(tc.title, tc.query)
+			if prefix != tc.wantPrefix || level != tc.wantLevel {
+				t.Errorf("matchedPrefix(%q, %q) = (%q, %q), want (%q, %q)",
+					tc.title, tc.query, prefix, level, tc.wantPrefix, tc.wantLevel)
+			}
+		})
+	}
+}